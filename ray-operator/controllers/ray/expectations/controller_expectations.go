@@ -0,0 +1,166 @@
+package expectations
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultExpectationsTimeout is the TTL applied to an expectation when the owner hasn't configured one
+// via SetExpirationTimeout. It bounds how long a missed watch event (a dropped create/delete) can wedge
+// reconciliation: once an expectation is older than the TTL it is treated as satisfied regardless of
+// whether every creation/deletion was actually observed.
+const DefaultExpectationsTimeout = 5 * time.Minute
+
+// ControllerExpectationsInterface tracks, per controllerKey, how many creations/deletions are still
+// outstanding before a controller should be considered caught up with the world it asked for.
+type ControllerExpectationsInterface interface {
+	ExpectCreations(controllerKey string, adds int) error
+	ExpectDeletions(controllerKey string, dels int) error
+
+	// CreationObserved and DeletionObserved report whether controllerKey actually had a pending
+	// creation/deletion to count against. Callers that turn this into a metric must only react when it's
+	// true — an unknown key (never armed) or one that's already fully observed has nothing pending, so
+	// counting it anyway would make "pending" drift below zero, e.g. when an informer relist redelivers
+	// every pre-existing object as if newly created.
+	CreationObserved(controllerKey string) bool
+	DeletionObserved(controllerKey string) bool
+
+	SatisfiedExpectations(controllerKey string) bool
+	// Expired reports whether the expectation for controllerKey outlived its TTL without every
+	// creation/deletion actually being observed. SatisfiedExpectations also returns true in that case,
+	// but Expired lets callers tell "really done" apart from "gave up waiting" for logging/metrics.
+	Expired(controllerKey string) bool
+
+	DeleteExpectations(controllerKey string)
+	SetExpirationTimeout(timeout time.Duration)
+	// ExpirationTimeout returns the TTL currently in effect, so other expectation kinds (e.g. slices) can
+	// apply the same timeout.
+	ExpirationTimeout() time.Duration
+}
+
+// controlleeExpectation is the add/delete counter pair for a single controllerKey, along with the
+// timestamp it was last (re)armed so it can be aged out by TTL.
+type controlleeExpectation struct {
+	addCount    int
+	delCount    int
+	observedAdd int
+	observedDel int
+	timestamp   time.Time
+}
+
+func (e *controlleeExpectation) observedFulfilled() bool {
+	return e.observedAdd >= e.addCount && e.observedDel >= e.delCount
+}
+
+func (e *controlleeExpectation) expired(ttl time.Duration) bool {
+	return time.Since(e.timestamp) > ttl
+}
+
+type ControllerExpectations struct {
+	mu    sync.RWMutex
+	name  string
+	ttl   time.Duration
+	store map[string]*controlleeExpectation
+}
+
+func NewControllerExpectations(name string) ControllerExpectationsInterface {
+	return &ControllerExpectations{
+		name:  name,
+		ttl:   DefaultExpectationsTimeout,
+		store: make(map[string]*controlleeExpectation),
+	}
+}
+
+func (e *ControllerExpectations) SetExpirationTimeout(timeout time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.ttl = timeout
+}
+
+func (e *ControllerExpectations) ExpirationTimeout() time.Duration {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.ttl
+}
+
+func (e *ControllerExpectations) ExpectCreations(controllerKey string, adds int) error {
+	e.arm(controllerKey, func(exp *controlleeExpectation) {
+		exp.addCount = adds
+		exp.observedAdd = 0
+	})
+	return nil
+}
+
+func (e *ControllerExpectations) ExpectDeletions(controllerKey string, dels int) error {
+	e.arm(controllerKey, func(exp *controlleeExpectation) {
+		exp.delCount = dels
+		exp.observedDel = 0
+	})
+	return nil
+}
+
+func (e *ControllerExpectations) arm(controllerKey string, mutate func(*controlleeExpectation)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	exp, ok := e.store[controllerKey]
+	if !ok {
+		exp = &controlleeExpectation{}
+		e.store[controllerKey] = exp
+	}
+	mutate(exp)
+	exp.timestamp = time.Now()
+}
+
+func (e *ControllerExpectations) CreationObserved(controllerKey string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	exp, ok := e.store[controllerKey]
+	if !ok || exp.observedAdd >= exp.addCount {
+		return false
+	}
+	exp.observedAdd++
+	if exp.observedFulfilled() {
+		expectationsFulfillmentSeconds.Observe(time.Since(exp.timestamp).Seconds())
+	}
+	return true
+}
+
+func (e *ControllerExpectations) DeletionObserved(controllerKey string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	exp, ok := e.store[controllerKey]
+	if !ok || exp.observedDel >= exp.delCount {
+		return false
+	}
+	exp.observedDel++
+	if exp.observedFulfilled() {
+		expectationsFulfillmentSeconds.Observe(time.Since(exp.timestamp).Seconds())
+	}
+	return true
+}
+
+func (e *ControllerExpectations) SatisfiedExpectations(controllerKey string) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	exp, ok := e.store[controllerKey]
+	if !ok {
+		return true
+	}
+	return exp.observedFulfilled() || exp.expired(e.ttl)
+}
+
+func (e *ControllerExpectations) Expired(controllerKey string) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	exp, ok := e.store[controllerKey]
+	if !ok {
+		return false
+	}
+	return !exp.observedFulfilled() && exp.expired(e.ttl)
+}
+
+func (e *ControllerExpectations) DeleteExpectations(controllerKey string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.store, controllerKey)
+}
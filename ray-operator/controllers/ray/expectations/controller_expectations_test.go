@@ -0,0 +1,56 @@
+package expectations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestControllerExpectationsSatisfiedOnObservation(t *testing.T) {
+	exp := NewControllerExpectations("test")
+	key := "default/raycluster-test/worker/group"
+
+	assert.NoError(t, exp.ExpectCreations(key, 2))
+	assert.False(t, exp.SatisfiedExpectations(key))
+	assert.True(t, exp.CreationObserved(key))
+	assert.False(t, exp.SatisfiedExpectations(key))
+	assert.True(t, exp.CreationObserved(key))
+	assert.True(t, exp.SatisfiedExpectations(key))
+	assert.False(t, exp.Expired(key), "expectation fulfilled by observation should not count as expired")
+
+	assert.False(t, exp.CreationObserved(key), "an already-fulfilled expectation has nothing pending left to observe")
+}
+
+func TestControllerExpectationsObservedReportsNoPendingForUnknownKey(t *testing.T) {
+	exp := NewControllerExpectations("test")
+	assert.False(t, exp.CreationObserved("never-armed"))
+	assert.False(t, exp.DeletionObserved("never-armed"))
+}
+
+func TestControllerExpectationsSatisfiedAfterTTL(t *testing.T) {
+	exp := NewControllerExpectations("test")
+	exp.SetExpirationTimeout(time.Millisecond)
+	key := "default/raycluster-test/worker/group"
+
+	assert.NoError(t, exp.ExpectCreations(key, 2))
+	assert.False(t, exp.SatisfiedExpectations(key))
+	time.Sleep(5 * time.Millisecond)
+	assert.True(t, exp.SatisfiedExpectations(key), "a stuck expectation must not wedge reconciliation forever")
+	assert.True(t, exp.Expired(key))
+}
+
+func TestControllerExpectationsUnknownKeyIsSatisfied(t *testing.T) {
+	exp := NewControllerExpectations("test")
+	assert.True(t, exp.SatisfiedExpectations("never-armed"))
+	assert.False(t, exp.Expired("never-armed"))
+}
+
+func TestControllerExpectationsDelete(t *testing.T) {
+	exp := NewControllerExpectations("test")
+	key := "default/raycluster-test/worker/group"
+	assert.NoError(t, exp.ExpectCreations(key, 1))
+	assert.False(t, exp.SatisfiedExpectations(key))
+	exp.DeleteExpectations(key)
+	assert.True(t, exp.SatisfiedExpectations(key))
+}
@@ -0,0 +1,34 @@
+package expectations
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// expectationsPending is the number of creations/deletions a (cluster,group) is still waiting to
+	// observe, broken down by action so a stuck create can be told apart from a stuck delete.
+	expectationsPending = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kuberay_expectations_pending",
+		Help: "Number of pod creations/deletions a RayCluster worker group expectation is still waiting to observe.",
+	}, []string{"cluster", "group", "action"})
+
+	// expectationsExpiredTotal counts expectations that were forced satisfied by TTL instead of by
+	// actually observing every creation/deletion, i.e. a likely missed watch event.
+	expectationsExpiredTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kuberay_expectations_expired_total",
+		Help: "Total number of expectations that were force-satisfied after exceeding their TTL.",
+	})
+
+	// expectationsFulfillmentSeconds measures how long it took an expectation to be observed as
+	// fulfilled, from the moment it was armed.
+	expectationsFulfillmentSeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "kuberay_expectations_fulfillment_seconds",
+		Help:    "Time between an expectation being armed and being observed as fulfilled.",
+		Buckets: prometheus.DefBuckets,
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(expectationsPending, expectationsExpiredTotal, expectationsFulfillmentSeconds)
+}
@@ -0,0 +1,79 @@
+package expectations
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// PodEventBridge keeps a RayClusterExpectationInterface in sync with the informer cache automatically,
+// so reconciler code paths that create or delete pods don't each need to remember to call Observed*
+// themselves. It watches for pods carrying the ray.io/cluster label, tells head from worker pods by the
+// ray.io/node-type label, and reports their creation/deletion as they land in the cache. The ray.io/group
+// label is not read; a worker pod's group is taken from its name.
+type PodEventBridge struct {
+	exp RayClusterExpectationInterface
+}
+
+// RegisterWithManager installs a Pod event handler on mgr's cache that drives exp automatically.
+func RegisterWithManager(mgr ctrl.Manager, exp RayClusterExpectationInterface) error {
+	informer, err := mgr.GetCache().GetInformer(context.Background(), &corev1.Pod{})
+	if err != nil {
+		return err
+	}
+
+	bridge := &PodEventBridge{exp: exp}
+	_, err = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    bridge.onAdd,
+		DeleteFunc: bridge.onDelete,
+	})
+	return err
+}
+
+func (b *PodEventBridge) onAdd(obj interface{}) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return
+	}
+	b.observe(pod, Create)
+}
+
+func (b *PodEventBridge) onDelete(obj interface{}) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return
+		}
+		pod, ok = tombstone.Obj.(*corev1.Pod)
+		if !ok {
+			return
+		}
+	}
+	b.observe(pod, Delete)
+}
+
+func (b *PodEventBridge) observe(pod *corev1.Pod, action Action) {
+	clusterName, ok := pod.Labels[utils.RayClusterLabelKey]
+	if !ok {
+		return
+	}
+	rayClusterKey := pod.Namespace + Separator + clusterName
+
+	switch pod.Labels[utils.RayNodeTypeLabelKey] {
+	case string(rayv1.HeadNode):
+		b.exp.ObservedHead(rayClusterKey, action)
+	case string(rayv1.WorkerNode):
+		// Observed re-parses pod.Name to tell a plain worker pod from a multi-host TPU slice pod
+		// (parseSliceIdentity) and routes to ObservedWorker/ObservedSlicePod accordingly. Calling
+		// ObservedWorker directly here would never credit a slice pod against its
+		// ExpectSliceCreations/ExpectSliceDeletions entry, leaving SatisfiedSliceExpectations to only ever
+		// go true by TTL expiry for clusters driven through this informer-backed path.
+		b.exp.Observed(pod.Namespace, pod.Name, action)
+	}
+}
@@ -0,0 +1,212 @@
+package expectations
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+type observedEvent struct {
+	rayClusterKey, group string
+	action               Action
+	kind                 string
+}
+
+// fakeExpectation records Observed* calls for assertions. It embeds the interface so it only needs to
+// override the methods PodEventBridge actually calls, plus Observed, which reproduces
+// RayClusterExpectation.Observed's head/worker/slice dispatch so the bridge's call through Observed exercises
+// the same routing it does in production.
+type fakeExpectation struct {
+	RayClusterExpectationInterface
+
+	mu       sync.Mutex
+	observed []observedEvent
+}
+
+func (f *fakeExpectation) ObservedHead(rayClusterKey string, action Action) {
+	f.record(rayClusterKey, DefaultHeadGroup, action, "head")
+}
+
+func (f *fakeExpectation) ObservedWorker(rayClusterKey, group string, action Action) {
+	f.record(rayClusterKey, group, action, "worker")
+}
+
+func (f *fakeExpectation) ObservedSlicePod(rayClusterKey, group, sliceID, podName string, action Action) {
+	f.record(rayClusterKey, group, action, "slice")
+}
+
+func (f *fakeExpectation) Observed(namespace, podName string, action Action) {
+	vals := strings.Split(podName, utils.DashSymbol)
+	group := DefaultHeadGroup
+	if len(vals) > 2 {
+		group = vals[2]
+	} else if len(vals) < 2 {
+		return
+	}
+	rayClusterKey := namespace + "/" + vals[0]
+
+	if group == DefaultHeadGroup {
+		f.ObservedHead(rayClusterKey, action)
+		return
+	}
+	if sliceGroup, sliceID, ok := parseSliceIdentity(podName); ok {
+		f.ObservedSlicePod(rayClusterKey, sliceGroup, sliceID, podName, action)
+		return
+	}
+	f.ObservedWorker(rayClusterKey, group, action)
+}
+
+func (f *fakeExpectation) record(rayClusterKey, group string, action Action, kind string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.observed = append(f.observed, observedEvent{rayClusterKey, group, action, kind})
+}
+
+func (f *fakeExpectation) snapshot() []observedEvent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make([]observedEvent, len(f.observed))
+	copy(out, f.observed)
+	return out
+}
+
+func workerPod(name, rayClusterName, group string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:   rayClusterName,
+				utils.RayNodeTypeLabelKey:  string(rayv1.WorkerNode),
+				utils.RayNodeGroupLabelKey: group,
+			},
+		},
+	}
+}
+
+func TestPodEventBridgeObservesCreateAndDeleteThroughInformer(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	fakeWatch := watch.NewFake()
+	clientset.PrependWatchReactor("pods", k8stesting.DefaultWatchReactor(fakeWatch, nil))
+
+	factory := informers.NewSharedInformerFactory(clientset, 0)
+	podInformer := factory.Core().V1().Pods().Informer()
+
+	exp := &fakeExpectation{}
+	bridge := &PodEventBridge{exp: exp}
+	_, err := podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    bridge.onAdd,
+		DeleteFunc: bridge.onDelete,
+	})
+	assert.NoError(t, err)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go podInformer.Run(stopCh)
+	assert.True(t, cache.WaitForCacheSync(stopCh, podInformer.HasSynced))
+
+	pod := workerPod("raycluster-worker-group-0", "raycluster", "group")
+	fakeWatch.Add(pod)
+	assert.Eventually(t, func() bool { return len(exp.snapshot()) == 1 }, time.Second, 10*time.Millisecond)
+
+	fakeWatch.Delete(pod)
+	assert.Eventually(t, func() bool { return len(exp.snapshot()) == 2 }, time.Second, 10*time.Millisecond)
+
+	events := exp.snapshot()
+	assert.Equal(t, observedEvent{"default/raycluster", "group", Create, "worker"}, events[0])
+	assert.Equal(t, observedEvent{"default/raycluster", "group", Delete, "worker"}, events[1])
+}
+
+func TestPodEventBridgeRoutesSliceStylePodsToObservedSlicePod(t *testing.T) {
+	exp := &fakeExpectation{}
+	bridge := &PodEventBridge{exp: exp}
+
+	pod := workerPod("raycluster-worker-tpu-0-0", "raycluster", "tpu")
+	bridge.onAdd(pod)
+
+	assert.Equal(t, []observedEvent{{"default/raycluster", "tpu", Create, "slice"}}, exp.snapshot(),
+		"a slice-style pod name must be credited to its slice expectation via ObservedSlicePod, not ObservedWorker")
+}
+
+func TestPodEventBridgeDoesNotReemitOnResync(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	fakeWatch := watch.NewFake()
+	clientset.PrependWatchReactor("pods", k8stesting.DefaultWatchReactor(fakeWatch, nil))
+
+	resyncPeriod := 20 * time.Millisecond
+	factory := informers.NewSharedInformerFactory(clientset, resyncPeriod)
+	podInformer := factory.Core().V1().Pods().Informer()
+
+	exp := &fakeExpectation{}
+	bridge := &PodEventBridge{exp: exp}
+	_, err := podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    bridge.onAdd,
+		DeleteFunc: bridge.onDelete,
+	})
+	assert.NoError(t, err)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go podInformer.Run(stopCh)
+	assert.True(t, cache.WaitForCacheSync(stopCh, podInformer.HasSynced))
+
+	pod := workerPod("raycluster-worker-group-0", "raycluster", "group")
+	fakeWatch.Add(pod)
+	assert.Eventually(t, func() bool { return len(exp.snapshot()) == 1 }, time.Second, 10*time.Millisecond)
+
+	// A periodic resync redelivers every already-known pod through UpdateFunc, which the bridge doesn't
+	// register, so waiting past several resync periods must not produce any more Observed* calls for a pod
+	// that's already steady-state.
+	time.Sleep(5 * resyncPeriod)
+	assert.Len(t, exp.snapshot(), 1, "a resync of an already-known pod must not re-emit Observed*")
+}
+
+func TestPodEventBridgeHandlesDeletedFinalStateUnknown(t *testing.T) {
+	exp := &fakeExpectation{}
+	bridge := &PodEventBridge{exp: exp}
+
+	pod := workerPod("raycluster-worker-group-0", "raycluster", "group")
+	bridge.onDelete(cache.DeletedFinalStateUnknown{Key: "default/raycluster-worker-group-0", Obj: pod})
+
+	events := exp.snapshot()
+	assert.Equal(t, []observedEvent{{"default/raycluster", "group", Delete, "worker"}}, events)
+}
+
+func TestPodEventBridgeIgnoresPodsWithoutRayClusterLabel(t *testing.T) {
+	exp := &fakeExpectation{}
+	bridge := &PodEventBridge{exp: exp}
+
+	bridge.onAdd(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "not-ray", Namespace: "default"}})
+	assert.Empty(t, exp.snapshot())
+}
+
+func TestPodEventBridgeObservesHeadPod(t *testing.T) {
+	exp := &fakeExpectation{}
+	bridge := &PodEventBridge{exp: exp}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "raycluster-head-abcde",
+			Namespace: "default",
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:  "raycluster",
+				utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
+			},
+		},
+	}
+	bridge.onAdd(pod)
+	assert.Equal(t, []observedEvent{{"default/raycluster", DefaultHeadGroup, Create, "head"}}, exp.snapshot())
+}
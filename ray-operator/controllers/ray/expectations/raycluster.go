@@ -1,16 +1,30 @@
 package expectations
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/sets"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 )
 
+// ErrSuspended is returned by ExpectHead*/ExpectWorker* while the RayCluster is suspended, so callers
+// don't record new pending creations/deletions that would race with the pause.
+var ErrSuspended = errors.New("rayCluster expectations are suspended")
+
+// janitorInterval is how often NewRayClusterExpectation's background janitor scans groupStore for
+// entries it can evict.
+const janitorInterval = time.Minute
+
+var log = logf.Log.WithName("expectations")
+
 type Action string
 
 const (
@@ -29,22 +43,95 @@ type RayClusterExpectationInterface interface {
 	ExpectWorkerDeletions(rayClusterKey, group string, dels int) error
 	ExpectWorkerCreations(rayClusterKey, group string, adds int) error
 
+	// ExpectSliceCreations and ExpectSliceDeletions record that hostsPerSlice pods must come up (or go
+	// down) together to form a valid multi-host TPU pod-slice. A slice is only satisfied once every host
+	// in it has been observed, so a partial slice never counts as progress.
+	ExpectSliceCreations(rayClusterKey, group, sliceID string, hostsPerSlice int) error
+	ExpectSliceDeletions(rayClusterKey, group, sliceID string, hostsPerSlice int) error
+
 	Observed(rayClusterKey, podName string, action Action)
 	ObservedHead(rayClusterKey string, action Action)
 	ObservedWorker(rayClusterKey, group string, action Action)
+	ObservedSlicePod(rayClusterKey, group, sliceID, podName string, action Action)
 
 	DeleteExpectations(rayClusterKey string)
 	DeleteHeadExpectations(rayClusterKey string)
 	DeleteWorkerExpectations(rayClusterKey, group string)
+	DeleteSliceExpectations(rayClusterKey, group, sliceID string)
 
 	SatisfiedHeadExpectations(rayClusterKey string) bool
 	SatisfiedWorkerExpectations(rayClusterKey, group string) bool
+	SatisfiedSliceExpectations(rayClusterKey, group, sliceID string) bool
+
+	// SetExpirationTimeout overrides the default TTL (DefaultExpectationsTimeout) after which a stuck
+	// expectation is treated as satisfied so a missed watch event can't wedge reconciliation forever.
+	SetExpirationTimeout(timeout time.Duration)
+
+	// SuspendExpectations/ResumeExpectations/IsSuspended gate ExpectHead*/ExpectWorker* so they can't
+	// record new pending pod scale operations while the RayCluster is paused. Pending expectations
+	// recorded before the suspend still have to be observed (or expire) before Satisfied* reports true,
+	// so the controller only treats the cluster as quiesced once in-flight operations have actually
+	// finished.
+	SuspendExpectations(rayClusterKey string)
+	ResumeExpectations(rayClusterKey string)
+	IsSuspended(rayClusterKey string) bool
+}
+
+// sliceExpectation tracks the hosts observed so far for a single multi-host TPU pod-slice, along with the
+// timestamp it was armed. It is satisfied once len(observed) reaches hostsPerSlice for the recorded
+// action, or once it's older than the TTL — the same missed-watch-event fallback every other expectation
+// kind gets from ControllerExpectations.
+type sliceExpectation struct {
+	rayClusterKey, group, sliceID string
+	action                        Action
+	hostsPerSlice                 int
+	observed                      sets.Set[string]
+	timestamp                     time.Time
+}
+
+func (e *sliceExpectation) fulfilled() bool {
+	return e.observed.Len() >= e.hostsPerSlice
+}
+
+func (e *sliceExpectation) expired(ttl time.Duration) bool {
+	return time.Since(e.timestamp) > ttl
 }
 
 type RayClusterExpectation struct {
 	mu         sync.RWMutex
 	groupStore map[string]sets.Set[string]
 	exp        ControllerExpectationsInterface
+
+	// sliceStore holds the pending expectation for each (cluster,group,sliceID), keyed by sliceKey.
+	sliceStore map[string]*sliceExpectation
+	// sliceIndex tracks which slice keys belong to a given rayClusterKey so DeleteExpectations can
+	// clean them all up without scanning sliceStore.
+	sliceIndex map[string]sets.Set[string]
+
+	// suspended holds the set of rayClusterKeys currently paused via SuspendExpectations.
+	suspended map[string]bool
+}
+
+func (re *RayClusterExpectation) SetExpirationTimeout(timeout time.Duration) {
+	re.exp.SetExpirationTimeout(timeout)
+}
+
+func (re *RayClusterExpectation) SuspendExpectations(rayClusterKey string) {
+	re.mu.Lock()
+	defer re.mu.Unlock()
+	re.suspended[rayClusterKey] = true
+}
+
+func (re *RayClusterExpectation) ResumeExpectations(rayClusterKey string) {
+	re.mu.Lock()
+	defer re.mu.Unlock()
+	delete(re.suspended, rayClusterKey)
+}
+
+func (re *RayClusterExpectation) IsSuspended(rayClusterKey string) bool {
+	re.mu.RLock()
+	defer re.mu.RUnlock()
+	return re.suspended[rayClusterKey]
 }
 
 func (re *RayClusterExpectation) SatisfiedHeadExpectations(rayClusterKey string) bool {
@@ -67,28 +154,45 @@ func (re *RayClusterExpectation) ExpectPodDeletions(namespace, podName string) e
 	}
 	rayClusterKey := namespace + "/" + vals[0]
 	re.recordGroup(rayClusterKey, group)
+	expectationsPending.WithLabelValues(rayClusterKey, group, string(Delete)).Set(1)
 	return re.exp.ExpectDeletions(rayClusterGroupKey(rayClusterKey, group), 1)
 }
 
 func (re *RayClusterExpectation) ExpectWorkerDeletions(rayClusterKey, group string, dels int) error {
+	if re.IsSuspended(rayClusterKey) {
+		return ErrSuspended
+	}
 	group = strings.ToLower(group)
 	re.recordGroup(rayClusterKey, group)
+	expectationsPending.WithLabelValues(rayClusterKey, group, string(Delete)).Set(float64(dels))
 	return re.exp.ExpectDeletions(rayClusterGroupKey(rayClusterKey, group), dels)
 }
 
 func (re *RayClusterExpectation) ExpectHeadDeletions(rayClusterKey string, dels int) error {
+	if re.IsSuspended(rayClusterKey) {
+		return ErrSuspended
+	}
 	re.recordGroup(rayClusterKey, DefaultHeadGroup)
+	expectationsPending.WithLabelValues(rayClusterKey, DefaultHeadGroup, string(Delete)).Set(float64(dels))
 	return re.exp.ExpectDeletions(rayClusterGroupKey(rayClusterKey, DefaultHeadGroup), dels)
 }
 
 func (re *RayClusterExpectation) ExpectWorkerCreations(rayClusterKey, group string, adds int) error {
+	if re.IsSuspended(rayClusterKey) {
+		return ErrSuspended
+	}
 	group = strings.ToLower(group)
 	re.recordGroup(rayClusterKey, group)
+	expectationsPending.WithLabelValues(rayClusterKey, group, string(Create)).Set(float64(adds))
 	return re.exp.ExpectCreations(rayClusterGroupKey(rayClusterKey, group), adds)
 }
 
 func (re *RayClusterExpectation) ExpectHeadCreations(rayClusterKey string, adds int) error {
+	if re.IsSuspended(rayClusterKey) {
+		return ErrSuspended
+	}
 	re.recordGroup(rayClusterKey, DefaultHeadGroup)
+	expectationsPending.WithLabelValues(rayClusterKey, DefaultHeadGroup, string(Create)).Set(float64(adds))
 	return re.exp.ExpectCreations(rayClusterGroupKey(rayClusterKey, DefaultHeadGroup), adds)
 }
 
@@ -96,6 +200,7 @@ func (re *RayClusterExpectation) Observed(namespace, podName string, action Acti
 	vals := strings.Split(podName, utils.DashSymbol)
 	// head   : {instance}-head-{Hash}
 	// worker : {instance}-worker-{Group}-{Hash}
+	// slice  : {instance}-worker-{Group}-{ReplicaIndex}-{HostIndex}
 	group := DefaultHeadGroup
 	if len(vals) > 2 {
 		group = vals[2]
@@ -106,42 +211,212 @@ func (re *RayClusterExpectation) Observed(namespace, podName string, action Acti
 
 	if group == DefaultHeadGroup {
 		re.ObservedHead(rayClusterKey, action)
+		return
+	}
+	if sliceGroup, sliceID, ok := parseSliceIdentity(podName); ok {
+		re.ObservedSlicePod(rayClusterKey, sliceGroup, sliceID, podName, action)
+		return
+	}
+	re.ObservedWorker(rayClusterKey, group, action)
+}
+
+// parseSliceIdentity extracts the (group, sliceID) pair from a multi-host TPU worker pod name of the
+// form {instance}-worker-{group}-{replicaIndex}-{hostIndex}, where sliceID is the replicaIndex shared by
+// every host in the slice. group itself may contain dashes (e.g. "tpu-v5e-group"), so this parses from the
+// tail: the last two dash-separated segments are always replicaIndex/hostIndex, and everything between the
+// "worker" segment and those two is the group. It returns ok=false for names that don't carry a host-index
+// segment, e.g. single-host workers ({instance}-worker-{group}-{hash}) or head pods.
+func parseSliceIdentity(podName string) (group, sliceID string, ok bool) {
+	vals := strings.Split(podName, utils.DashSymbol)
+	workerIdx := -1
+	for i, v := range vals {
+		if v == string(rayv1.WorkerNode) {
+			workerIdx = i
+			break
+		}
+	}
+	if workerIdx == -1 {
+		return "", "", false
+	}
+	rest := vals[workerIdx+1:]
+	// rest must be {group...}-{replicaIndex}-{hostIndex}, i.e. at least a one-segment group plus the two
+	// index segments.
+	if len(rest) < 3 {
+		return "", "", false
+	}
+	for _, idx := range rest[len(rest)-2:] {
+		if _, err := strconv.Atoi(idx); err != nil {
+			return "", "", false
+		}
+	}
+	group = strings.Join(rest[:len(rest)-2], utils.DashSymbol)
+	sliceID = rest[len(rest)-2]
+	return group, sliceID, true
+}
+
+func (re *RayClusterExpectation) ExpectSliceCreations(rayClusterKey, group, sliceID string, hostsPerSlice int) error {
+	return re.expectSlice(rayClusterKey, group, sliceID, hostsPerSlice, Create)
+}
+
+func (re *RayClusterExpectation) ExpectSliceDeletions(rayClusterKey, group, sliceID string, hostsPerSlice int) error {
+	return re.expectSlice(rayClusterKey, group, sliceID, hostsPerSlice, Delete)
+}
+
+func (re *RayClusterExpectation) expectSlice(rayClusterKey, group, sliceID string, hostsPerSlice int, action Action) error {
+	if re.IsSuspended(rayClusterKey) {
+		return ErrSuspended
+	}
+	if hostsPerSlice <= 0 {
+		return fmt.Errorf("hostsPerSlice must be positive, got %d", hostsPerSlice)
+	}
+	group = strings.ToLower(group)
+	re.recordGroup(rayClusterKey, group)
+
+	re.mu.Lock()
+	defer re.mu.Unlock()
+	key := sliceKey(rayClusterKey, group, sliceID)
+	// Re-arming an already-tracked slice (e.g. the reconciler retrying the same scale operation) must not
+	// double-count against the gauge, which is shared by every slice in (cluster,group,action) — back out
+	// whatever this slice key previously contributed before adding its fresh count.
+	if prev, ok := re.sliceStore[key]; ok {
+		if remaining := prev.hostsPerSlice - prev.observed.Len(); remaining > 0 {
+			expectationsPending.WithLabelValues(rayClusterKey, sliceMetricGroup(group), string(prev.action)).Sub(float64(remaining))
+		}
+	}
+	slices, ok := re.sliceIndex[rayClusterKey]
+	if !ok || slices == nil {
+		slices = sets.New[string](key)
+		re.sliceIndex[rayClusterKey] = slices
 	} else {
-		re.ObservedWorker(rayClusterKey, group, action)
+		slices.Insert(key)
+	}
+	re.sliceStore[key] = &sliceExpectation{
+		rayClusterKey: rayClusterKey,
+		group:         group,
+		sliceID:       sliceID,
+		action:        action,
+		hostsPerSlice: hostsPerSlice,
+		observed:      sets.New[string](),
+		timestamp:     time.Now(),
+	}
+	expectationsPending.WithLabelValues(rayClusterKey, sliceMetricGroup(group), string(action)).Add(float64(hostsPerSlice))
+	return nil
+}
+
+func (re *RayClusterExpectation) ObservedSlicePod(rayClusterKey, group, sliceID, podName string, action Action) {
+	group = strings.ToLower(group)
+	re.mu.Lock()
+	defer re.mu.Unlock()
+	exp, ok := re.sliceStore[sliceKey(rayClusterKey, group, sliceID)]
+	if !ok || exp.action != action || exp.observed.Has(podName) {
+		return
+	}
+	exp.observed.Insert(podName)
+	expectationsPending.WithLabelValues(rayClusterKey, sliceMetricGroup(group), string(action)).Dec()
+	if exp.fulfilled() {
+		expectationsFulfillmentSeconds.Observe(time.Since(exp.timestamp).Seconds())
+	}
+}
+
+func (re *RayClusterExpectation) SatisfiedSliceExpectations(rayClusterKey, group, sliceID string) bool {
+	group = strings.ToLower(group)
+	re.mu.RLock()
+	defer re.mu.RUnlock()
+	exp, ok := re.sliceStore[sliceKey(rayClusterKey, group, sliceID)]
+	if !ok {
+		return true
+	}
+	return exp.fulfilled() || exp.expired(re.exp.ExpirationTimeout())
+}
+
+func (re *RayClusterExpectation) DeleteSliceExpectations(rayClusterKey, group, sliceID string) {
+	group = strings.ToLower(group)
+	re.mu.Lock()
+	defer re.mu.Unlock()
+	re.deleteSliceLocked(rayClusterKey, group, sliceID)
+}
+
+// deleteSliceLocked removes the slice expectation for (rayClusterKey,group,sliceID) and brings the
+// pending gauge back down by however many hosts it was still waiting on. Callers must hold re.mu.
+func (re *RayClusterExpectation) deleteSliceLocked(rayClusterKey, group, sliceID string) {
+	key := sliceKey(rayClusterKey, group, sliceID)
+	if exp, ok := re.sliceStore[key]; ok {
+		if remaining := exp.hostsPerSlice - exp.observed.Len(); remaining > 0 {
+			expectationsPending.WithLabelValues(rayClusterKey, sliceMetricGroup(group), string(exp.action)).Sub(float64(remaining))
+		}
+		delete(re.sliceStore, key)
+	}
+	if slices, ok := re.sliceIndex[rayClusterKey]; ok {
+		slices.Delete(key)
 	}
 }
 
+// sliceMetricGroup returns the expectationsPending "group" label used for multi-host TPU pod-slices. A
+// group's own label (used by ExpectWorkerCreations/ExpectWorkerDeletions/ObservedWorker) is set
+// absolutely via Set, while slices accumulate incrementally via Add/Sub/Dec; giving slices a distinct
+// label value means the two bookkeeping styles can never stomp on the same gauge series, even if a group
+// is ever tracked through both mechanisms.
+func sliceMetricGroup(group string) string {
+	return group + "/slice"
+}
+
 func (re *RayClusterExpectation) ObservedHead(rayClusterKey string, action Action) {
 	key := rayClusterGroupKey(rayClusterKey, DefaultHeadGroup)
+	var pending bool
 	switch action {
 	case Create:
-		re.exp.CreationObserved(key)
+		pending = re.exp.CreationObserved(key)
 	case Delete:
-		re.exp.DeletionObserved(key)
+		pending = re.exp.DeletionObserved(key)
+	}
+	// Only move the gauge when there was actually something pending to count against: an informer relist
+	// redelivers every already-running pod through the same code path, and that must not be mistaken for a
+	// new creation/deletion or the gauge would drift negative.
+	if pending {
+		expectationsPending.WithLabelValues(rayClusterKey, DefaultHeadGroup, string(action)).Dec()
 	}
 }
 
 func (re *RayClusterExpectation) ObservedWorker(rayClusterKey, group string, action Action) {
 	key := rayClusterGroupKey(rayClusterKey, group)
+	var pending bool
 	switch action {
 	case Create:
-		re.exp.CreationObserved(key)
+		pending = re.exp.CreationObserved(key)
 	case Delete:
-		re.exp.DeletionObserved(key)
+		pending = re.exp.DeletionObserved(key)
+	}
+	if pending {
+		expectationsPending.WithLabelValues(rayClusterKey, group, string(action)).Dec()
 	}
 }
 
 func (re *RayClusterExpectation) DeleteExpectations(rayClusterKey string) {
 	re.mu.Lock()
 	defer re.mu.Unlock()
-	groups, ok := re.groupStore[rayClusterKey]
-	if !ok || groups == nil {
-		return
+	if groups, ok := re.groupStore[rayClusterKey]; ok && groups != nil {
+		for group := range groups {
+			re.exp.DeleteExpectations(rayClusterGroupKey(rayClusterKey, group))
+			expectationsPending.DeleteLabelValues(rayClusterKey, group, string(Create))
+			expectationsPending.DeleteLabelValues(rayClusterKey, group, string(Delete))
+		}
+		delete(re.groupStore, rayClusterKey)
 	}
-	for group := range groups {
-		re.exp.DeleteExpectations(rayClusterGroupKey(rayClusterKey, group))
+	if slices, ok := re.sliceIndex[rayClusterKey]; ok {
+		sliceGroups := sets.New[string]()
+		for key := range slices {
+			if exp, ok := re.sliceStore[key]; ok {
+				sliceGroups.Insert(exp.group)
+			}
+			delete(re.sliceStore, key)
+		}
+		for group := range sliceGroups {
+			expectationsPending.DeleteLabelValues(rayClusterKey, sliceMetricGroup(group), string(Create))
+			expectationsPending.DeleteLabelValues(rayClusterKey, sliceMetricGroup(group), string(Delete))
+		}
+		delete(re.sliceIndex, rayClusterKey)
 	}
-	delete(re.groupStore, rayClusterKey)
+	delete(re.suspended, rayClusterKey)
 }
 
 func (re *RayClusterExpectation) DeleteHeadExpectations(rayClusterKey string) {
@@ -153,6 +428,8 @@ func (re *RayClusterExpectation) DeleteHeadExpectations(rayClusterKey string) {
 	}
 	groups.Delete(DefaultHeadGroup)
 	re.exp.DeleteExpectations(rayClusterGroupKey(rayClusterKey, DefaultHeadGroup))
+	expectationsPending.DeleteLabelValues(rayClusterKey, DefaultHeadGroup, string(Create))
+	expectationsPending.DeleteLabelValues(rayClusterKey, DefaultHeadGroup, string(Delete))
 }
 
 func (re *RayClusterExpectation) DeleteWorkerExpectations(rayClusterKey, group string) {
@@ -164,6 +441,8 @@ func (re *RayClusterExpectation) DeleteWorkerExpectations(rayClusterKey, group s
 	}
 	groups.Delete(group)
 	re.exp.DeleteExpectations(rayClusterGroupKey(rayClusterKey, group))
+	expectationsPending.DeleteLabelValues(rayClusterKey, group, string(Create))
+	expectationsPending.DeleteLabelValues(rayClusterKey, group, string(Delete))
 }
 
 func (re *RayClusterExpectation) recordGroup(rayClusterKey, group string) {
@@ -187,9 +466,107 @@ func rayClusterGroupKey(rayClusterKey, group string) (key string) {
 	return key
 }
 
+// sliceKey identifies a single multi-host TPU pod-slice within a worker group, e.g.
+// "{cluster}/worker/{group}/slice/{sliceID}".
+func sliceKey(rayClusterKey, group, sliceID string) string {
+	return rayClusterGroupKey(rayClusterKey, group) + Separator + "slice" + Separator + sliceID
+}
+
+// runJanitor periodically scans groupStore and sliceStore and evicts entries whose underlying expectation
+// is already satisfied, so stale bookkeeping doesn't accumulate for clusters/groups/slices the controller
+// never explicitly cleaned up with DeleteWorkerExpectations/DeleteHeadExpectations/DeleteSliceExpectations.
+// Entries evicted because they expired rather than because every creation/deletion was actually observed
+// are logged, since that usually means a watch event was missed.
+func (re *RayClusterExpectation) runJanitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for range ticker.C {
+			re.evictSatisfied()
+			re.evictSatisfiedSlices()
+		}
+	}()
+}
+
+func (re *RayClusterExpectation) evictSatisfied() {
+	type groupRef struct {
+		rayClusterKey, group string
+	}
+
+	re.mu.RLock()
+	refs := make([]groupRef, 0, len(re.groupStore))
+	for rayClusterKey, groups := range re.groupStore {
+		for group := range groups {
+			refs = append(refs, groupRef{rayClusterKey, group})
+		}
+	}
+	re.mu.RUnlock()
+
+	for _, ref := range refs {
+		key := rayClusterGroupKey(ref.rayClusterKey, ref.group)
+		if !re.exp.SatisfiedExpectations(key) {
+			continue
+		}
+		if re.exp.Expired(key) {
+			expectationsExpiredTotal.Inc()
+			log.Info("warning: evicting expectation after TTL expiry, a watch event may have been missed",
+				"rayCluster", ref.rayClusterKey, "group", ref.group)
+		}
+		if ref.group == DefaultHeadGroup {
+			re.DeleteHeadExpectations(ref.rayClusterKey)
+		} else {
+			re.DeleteWorkerExpectations(ref.rayClusterKey, ref.group)
+		}
+	}
+}
+
+// evictSatisfiedSlices is evictSatisfied's counterpart for multi-host TPU pod-slices: without it, a slice
+// that's satisfied (or whose TTL has expired after a missed watch event) would never be evicted, since
+// evictSatisfied only scans groupStore.
+func (re *RayClusterExpectation) evictSatisfiedSlices() {
+	type sliceRef struct {
+		rayClusterKey, group, sliceID string
+	}
+
+	ttl := re.exp.ExpirationTimeout()
+	re.mu.RLock()
+	refs := make([]sliceRef, 0, len(re.sliceStore))
+	for _, exp := range re.sliceStore {
+		refs = append(refs, sliceRef{exp.rayClusterKey, exp.group, exp.sliceID})
+	}
+	re.mu.RUnlock()
+
+	for _, ref := range refs {
+		re.mu.Lock()
+		exp, ok := re.sliceStore[sliceKey(ref.rayClusterKey, ref.group, ref.sliceID)]
+		if !ok {
+			re.mu.Unlock()
+			continue
+		}
+		fulfilled := exp.fulfilled()
+		expired := exp.expired(ttl)
+		if !fulfilled && !expired {
+			re.mu.Unlock()
+			continue
+		}
+		if !fulfilled && expired {
+			expectationsExpiredTotal.Inc()
+			log.Info("warning: evicting slice expectation after TTL expiry, a watch event may have been missed",
+				"rayCluster", ref.rayClusterKey, "group", ref.group, "slice", ref.sliceID)
+		}
+		re.deleteSliceLocked(ref.rayClusterKey, ref.group, ref.sliceID)
+		re.mu.Unlock()
+	}
+}
+
 func NewRayClusterExpectation(name string) RayClusterExpectationInterface {
-	return &RayClusterExpectation{
+	re := &RayClusterExpectation{
 		groupStore: make(map[string]sets.Set[string]),
 		exp:        NewControllerExpectations(name),
+		sliceStore: make(map[string]*sliceExpectation),
+		sliceIndex: make(map[string]sets.Set[string]),
+		suspended:  make(map[string]bool),
 	}
+	re.runJanitor(janitorInterval)
+	return re
 }
@@ -0,0 +1,159 @@
+package expectations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func newTestRayClusterExpectation() *RayClusterExpectation {
+	return &RayClusterExpectation{
+		groupStore: make(map[string]sets.Set[string]),
+		exp:        NewControllerExpectations("test"),
+		sliceStore: make(map[string]*sliceExpectation),
+		sliceIndex: make(map[string]sets.Set[string]),
+		suspended:  make(map[string]bool),
+	}
+}
+
+func TestSliceExpectationsRequireEveryHost(t *testing.T) {
+	re := newTestRayClusterExpectation()
+	rayClusterKey := "default/raycluster-test"
+	group := "tpu-group"
+	sliceID := "0"
+
+	assert.NoError(t, re.ExpectSliceCreations(rayClusterKey, group, sliceID, 4))
+	assert.False(t, re.SatisfiedSliceExpectations(rayClusterKey, group, sliceID))
+
+	re.ObservedSlicePod(rayClusterKey, group, sliceID, "raycluster-test-worker-tpu-group-0-0", Create)
+	re.ObservedSlicePod(rayClusterKey, group, sliceID, "raycluster-test-worker-tpu-group-0-1", Create)
+	re.ObservedSlicePod(rayClusterKey, group, sliceID, "raycluster-test-worker-tpu-group-0-2", Create)
+	assert.False(t, re.SatisfiedSliceExpectations(rayClusterKey, group, sliceID), "partial slice must not count as progress")
+
+	re.ObservedSlicePod(rayClusterKey, group, sliceID, "raycluster-test-worker-tpu-group-0-3", Create)
+	assert.True(t, re.SatisfiedSliceExpectations(rayClusterKey, group, sliceID))
+}
+
+func TestSliceExpectationsIgnoreMismatchedAction(t *testing.T) {
+	re := newTestRayClusterExpectation()
+	rayClusterKey := "default/raycluster-test"
+	group := "tpu-group"
+	sliceID := "0"
+
+	assert.NoError(t, re.ExpectSliceCreations(rayClusterKey, group, sliceID, 1))
+	re.ObservedSlicePod(rayClusterKey, group, sliceID, "raycluster-test-worker-tpu-group-0-0", Delete)
+	assert.False(t, re.SatisfiedSliceExpectations(rayClusterKey, group, sliceID))
+}
+
+func TestSliceExpectationsRejectNonPositiveHostsPerSlice(t *testing.T) {
+	re := newTestRayClusterExpectation()
+	assert.Error(t, re.ExpectSliceCreations("default/raycluster-test", "tpu-group", "0", 0))
+}
+
+func TestDeleteSliceExpectations(t *testing.T) {
+	re := newTestRayClusterExpectation()
+	rayClusterKey := "default/raycluster-test"
+	group := "tpu-group"
+	sliceID := "0"
+
+	assert.NoError(t, re.ExpectSliceCreations(rayClusterKey, group, sliceID, 2))
+	assert.False(t, re.SatisfiedSliceExpectations(rayClusterKey, group, sliceID))
+
+	re.DeleteSliceExpectations(rayClusterKey, group, sliceID)
+	assert.True(t, re.SatisfiedSliceExpectations(rayClusterKey, group, sliceID))
+}
+
+func TestDeleteExpectationsCascadesToSlices(t *testing.T) {
+	re := newTestRayClusterExpectation()
+	rayClusterKey := "default/raycluster-test"
+	group := "tpu-group"
+
+	assert.NoError(t, re.ExpectSliceCreations(rayClusterKey, group, "0", 2))
+	assert.NoError(t, re.ExpectSliceCreations(rayClusterKey, group, "1", 2))
+	re.DeleteExpectations(rayClusterKey)
+	assert.True(t, re.SatisfiedSliceExpectations(rayClusterKey, group, "0"))
+	assert.True(t, re.SatisfiedSliceExpectations(rayClusterKey, group, "1"))
+	assert.Empty(t, re.sliceIndex[rayClusterKey])
+}
+
+func TestObservedRoutesSliceStylePodNamesToSliceExpectations(t *testing.T) {
+	re := newTestRayClusterExpectation()
+	namespace := "default"
+	instance := "raycluster"
+	rayClusterKey := namespace + "/" + instance
+	group := "tpu"
+	sliceID := "0"
+
+	assert.NoError(t, re.ExpectSliceCreations(rayClusterKey, group, sliceID, 2))
+	re.Observed(namespace, instance+"-worker-tpu-0-0", Create)
+	assert.False(t, re.SatisfiedSliceExpectations(rayClusterKey, group, sliceID))
+	re.Observed(namespace, instance+"-worker-tpu-0-1", Create)
+	assert.True(t, re.SatisfiedSliceExpectations(rayClusterKey, group, sliceID))
+}
+
+func TestSliceExpectationsSatisfiedAfterTTL(t *testing.T) {
+	re := newTestRayClusterExpectation()
+	re.SetExpirationTimeout(time.Millisecond)
+	rayClusterKey := "default/raycluster-test"
+	group := "tpu-group"
+	sliceID := "0"
+
+	assert.NoError(t, re.ExpectSliceCreations(rayClusterKey, group, sliceID, 4))
+	re.ObservedSlicePod(rayClusterKey, group, sliceID, "raycluster-test-worker-tpu-group-0-0", Create)
+	assert.False(t, re.SatisfiedSliceExpectations(rayClusterKey, group, sliceID), "a missed watch event for the remaining hosts must not wedge the slice forever")
+
+	time.Sleep(5 * time.Millisecond)
+	assert.True(t, re.SatisfiedSliceExpectations(rayClusterKey, group, sliceID))
+}
+
+func TestJanitorEvictsSatisfiedAndExpiredSlices(t *testing.T) {
+	re := newTestRayClusterExpectation()
+	re.SetExpirationTimeout(time.Millisecond)
+	rayClusterKey := "default/raycluster-test"
+	group := "tpu-group"
+
+	assert.NoError(t, re.ExpectSliceCreations(rayClusterKey, group, "0", 1))
+	re.ObservedSlicePod(rayClusterKey, group, "0", "raycluster-test-worker-tpu-group-0-0", Create)
+	assert.NoError(t, re.ExpectSliceCreations(rayClusterKey, group, "1", 4))
+
+	time.Sleep(5 * time.Millisecond)
+	re.evictSatisfiedSlices()
+
+	assert.Empty(t, re.sliceStore, "both the fulfilled slice and the expired-but-unfulfilled slice should be evicted")
+	assert.Empty(t, re.sliceIndex[rayClusterKey])
+}
+
+func TestSliceAndGroupExpectationsDoNotShareAGaugeLabel(t *testing.T) {
+	re := newTestRayClusterExpectation()
+	// expectationsPending is process-global and earlier tests leave slices pending under
+	// "default/raycluster-test", so this test uses a cluster key of its own.
+	rayClusterKey := "default/raycluster-gauge-label-test"
+	group := "tpu-group"
+
+	assert.NoError(t, re.ExpectWorkerCreations(rayClusterKey, group, 3))
+	assert.NoError(t, re.ExpectSliceCreations(rayClusterKey, group, "0", 4))
+
+	assert.Equal(t, float64(3), testutil.ToFloat64(expectationsPending.WithLabelValues(rayClusterKey, group, string(Create))),
+		"a slice sharing a group name must not stomp on the group's own Set-based gauge series")
+	assert.Equal(t, float64(4), testutil.ToFloat64(expectationsPending.WithLabelValues(rayClusterKey, sliceMetricGroup(group), string(Create))))
+}
+
+func TestParseSliceIdentity(t *testing.T) {
+	group, sliceID, ok := parseSliceIdentity("raycluster-worker-tpu-0-1")
+	assert.True(t, ok)
+	assert.Equal(t, "tpu", group)
+	assert.Equal(t, "0", sliceID)
+
+	_, _, ok = parseSliceIdentity("raycluster-worker-tpu-hash")
+	assert.False(t, ok)
+}
+
+func TestParseSliceIdentityHandlesHyphenatedGroupNames(t *testing.T) {
+	group, sliceID, ok := parseSliceIdentity("raycluster-worker-tpu-v5e-group-0-1")
+	assert.True(t, ok)
+	assert.Equal(t, "tpu-v5e-group", group)
+	assert.Equal(t, "0", sliceID)
+}
@@ -0,0 +1,112 @@
+package expectations
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// HeadGroup is the group name used to key scale expectations for the head pod. It mirrors
+// DefaultHeadGroup so callers don't need to special-case the head group.
+const HeadGroup = DefaultHeadGroup
+
+// RayClusterScaleExpectationInterface checks expected pod creations/deletions directly against a
+// controller-runtime client (backed by the informer cache), instead of requiring every call site
+// that creates or deletes a pod to also call an Observed* method.
+type RayClusterScaleExpectationInterface interface {
+	// ExpectScalePod records that podName in namespace is expected to be created or deleted for the
+	// given RayCluster/group.
+	ExpectScalePod(clusterName, group, namespace, podName string, action Action)
+	// IsSatisfied reports whether every pod expected for (clusterName, group, namespace) has been
+	// observed in the client cache with the expected action. Expectations that are satisfied are
+	// cleared so repeated calls don't keep re-checking stale pod names.
+	IsSatisfied(ctx context.Context, clusterName, group, namespace string) bool
+	// Delete clears all pending expectations for clusterName in namespace, regardless of group.
+	Delete(clusterName, namespace string)
+}
+
+type podScaleExpectation struct {
+	name   string
+	action Action
+}
+
+// RayClusterScaleExpectation implements RayClusterScaleExpectationInterface.
+type RayClusterScaleExpectation struct {
+	mu     sync.RWMutex
+	client client.Client
+	store  map[string][]podScaleExpectation
+}
+
+func NewRayClusterScaleExpectation(client client.Client) RayClusterScaleExpectationInterface {
+	return &RayClusterScaleExpectation{
+		client: client,
+		store:  make(map[string][]podScaleExpectation),
+	}
+}
+
+func (r *RayClusterScaleExpectation) ExpectScalePod(clusterName, group, namespace, podName string, action Action) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := scalePodGroupKey(clusterName, group, namespace)
+	r.store[key] = append(r.store[key], podScaleExpectation{name: podName, action: action})
+}
+
+func (r *RayClusterScaleExpectation) IsSatisfied(ctx context.Context, clusterName, group, namespace string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := scalePodGroupKey(clusterName, group, namespace)
+	pending, ok := r.store[key]
+	if !ok || len(pending) == 0 {
+		return true
+	}
+
+	remaining := pending[:0]
+	for _, exp := range pending {
+		if !r.observed(ctx, namespace, exp) {
+			remaining = append(remaining, exp)
+		}
+	}
+	if len(remaining) == 0 {
+		delete(r.store, key)
+		return true
+	}
+	r.store[key] = remaining
+	return false
+}
+
+func (r *RayClusterScaleExpectation) observed(ctx context.Context, namespace string, exp podScaleExpectation) bool {
+	pod := &corev1.Pod{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: exp.name}, pod)
+	switch exp.action {
+	case Create:
+		return err == nil
+	case Delete:
+		return apierrors.IsNotFound(err)
+	default:
+		return false
+	}
+}
+
+func (r *RayClusterScaleExpectation) Delete(clusterName, namespace string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	prefix := scalePodGroupKeyPrefix(clusterName, namespace)
+	for key := range r.store {
+		if strings.HasPrefix(key, prefix) {
+			delete(r.store, key)
+		}
+	}
+}
+
+func scalePodGroupKeyPrefix(clusterName, namespace string) string {
+	return namespace + Separator + clusterName + Separator
+}
+
+func scalePodGroupKey(clusterName, group, namespace string) string {
+	return scalePodGroupKeyPrefix(clusterName, namespace) + group
+}
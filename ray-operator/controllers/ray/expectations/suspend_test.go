@@ -0,0 +1,79 @@
+package expectations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRayClusterExpectationWithController() *RayClusterExpectation {
+	re := newTestRayClusterExpectation()
+	re.exp = NewControllerExpectations("test")
+	return re
+}
+
+func TestSuspendBlocksNewExpectations(t *testing.T) {
+	re := newTestRayClusterExpectationWithController()
+	rayClusterKey := "default/raycluster-test"
+
+	re.SuspendExpectations(rayClusterKey)
+	assert.True(t, re.IsSuspended(rayClusterKey))
+	assert.ErrorIs(t, re.ExpectHeadCreations(rayClusterKey, 1), ErrSuspended)
+	assert.ErrorIs(t, re.ExpectWorkerCreations(rayClusterKey, "group", 1), ErrSuspended)
+	assert.ErrorIs(t, re.ExpectHeadDeletions(rayClusterKey, 1), ErrSuspended)
+	assert.ErrorIs(t, re.ExpectWorkerDeletions(rayClusterKey, "group", 1), ErrSuspended)
+}
+
+func TestSuspendBlocksSliceExpectations(t *testing.T) {
+	re := newTestRayClusterExpectationWithController()
+	rayClusterKey := "default/raycluster-test"
+
+	re.SuspendExpectations(rayClusterKey)
+	assert.ErrorIs(t, re.ExpectSliceCreations(rayClusterKey, "tpu-group", "0", 4), ErrSuspended)
+	assert.ErrorIs(t, re.ExpectSliceDeletions(rayClusterKey, "tpu-group", "0", 4), ErrSuspended)
+}
+
+func TestResumeExpectationsUnblocks(t *testing.T) {
+	re := newTestRayClusterExpectationWithController()
+	rayClusterKey := "default/raycluster-test"
+
+	re.SuspendExpectations(rayClusterKey)
+	re.ResumeExpectations(rayClusterKey)
+	assert.False(t, re.IsSuspended(rayClusterKey))
+	assert.NoError(t, re.ExpectHeadCreations(rayClusterKey, 1))
+}
+
+func TestSuspendDoesNotForceAlreadyPendingExpectationsSatisfied(t *testing.T) {
+	re := newTestRayClusterExpectationWithController()
+	rayClusterKey := "default/raycluster-test"
+
+	assert.NoError(t, re.ExpectHeadCreations(rayClusterKey, 1))
+	re.SuspendExpectations(rayClusterKey)
+	assert.False(t, re.SatisfiedHeadExpectations(rayClusterKey), "pending creation recorded before suspend must still be observed")
+
+	re.ObservedHead(rayClusterKey, Create)
+	assert.True(t, re.SatisfiedHeadExpectations(rayClusterKey))
+}
+
+func TestSuspendQuiescesOncePendingExpectationExpires(t *testing.T) {
+	re := newTestRayClusterExpectationWithController()
+	re.SetExpirationTimeout(time.Millisecond)
+	rayClusterKey := "default/raycluster-test"
+
+	assert.NoError(t, re.ExpectWorkerCreations(rayClusterKey, "group", 1))
+	re.SuspendExpectations(rayClusterKey)
+	assert.False(t, re.SatisfiedWorkerExpectations(rayClusterKey, "group"))
+
+	time.Sleep(5 * time.Millisecond)
+	assert.True(t, re.SatisfiedWorkerExpectations(rayClusterKey, "group"))
+}
+
+func TestDeleteExpectationsClearsSuspension(t *testing.T) {
+	re := newTestRayClusterExpectationWithController()
+	rayClusterKey := "default/raycluster-test"
+
+	re.SuspendExpectations(rayClusterKey)
+	re.DeleteExpectations(rayClusterKey)
+	assert.False(t, re.IsSuspended(rayClusterKey))
+}